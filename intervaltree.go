@@ -1,6 +1,9 @@
 package intervaltree
 
 import (
+	"cmp"
+	"iter"
+	"sort"
 	"sync"
 )
 
@@ -8,9 +11,85 @@ import (
 const imbalanceThreshold = 1
 
 // Interval represents an interval with a key of type T and values of type []V.
+//
+// Key is the low (Begin) endpoint of the range and is what the tree is
+// ordered by. End is the exclusive high endpoint of the range, so the
+// interval covers [Key, End); it is meaningful on its own when Interval is
+// used as a simple range descriptor (a Difference gap, or the iv argument to
+// InsertOrConflict/Conflicts). Entries inserted through the point-key API
+// (Insert) are degenerate ranges with End equal to Key.
+//
+// Values holds the values stored at Key, each paired with its own End:
+// distinct InsertRange calls that happen to share a Key (the normal case for
+// NewIntervalTree, whose default collision handler is Append) commonly cover
+// different ranges, so a single End shared across all of them would be
+// wrong.
 type Interval[T comparable, V any] struct {
 	Key    T
-	Values []V
+	End    T
+	Values []ValueEnd[T, V]
+}
+
+// ValueEnd pairs a stored value with the End of the range it was inserted
+// under.
+type ValueEnd[T comparable, V any] struct {
+	End   T
+	Value V
+}
+
+// BoundKind describes how a Bound constrains its side of a Range.
+type BoundKind uint8
+
+const (
+	// Unbounded means the range extends to infinity on this side.
+	Unbounded BoundKind = iota
+	// Inclusive means the range includes Value on this side.
+	Inclusive
+	// Exclusive means the range approaches but does not include Value.
+	Exclusive
+)
+
+// Bound is one endpoint of a Range: either Unbounded, or a Value that is
+// either Inclusive or Exclusive.
+type Bound[T any] struct {
+	Kind  BoundKind
+	Value T
+}
+
+// Range is a generalized interval that, unlike the tree's native [Key, End)
+// ranges, can be open-ended or inclusive/exclusive on either side -- e.g.
+// "any time after 3pm" or "all addresses >= 10.0.0.0". The tree itself is
+// still ordered and pruned by T via Comparator; Range/Bound only participate
+// in the Overlaps check, not in tree ordering, so there's no equivalent of
+// maxEnd pruning for them. See InsertBounded and OverlapBounded.
+type Range[T any] struct {
+	Lo, Hi Bound[T]
+}
+
+// Overlaps reports whether a and b share any point, treating Unbounded as
+// infinity on the relevant side and respecting Inclusive/Exclusive bounds at
+// shared endpoints: two ranges that merely abut at an Exclusive boundary do
+// not overlap.
+func Overlaps[T any](a, b Range[T], less func(a, b T) bool) bool {
+	return !hiBeforeLo(a.Hi, b.Lo, less) && !hiBeforeLo(b.Hi, a.Lo, less)
+}
+
+// hiBeforeLo reports whether hi ends strictly before lo begins, i.e. there is
+// no point satisfying both "at or before hi" and "at or after lo".
+func hiBeforeLo[T any](hi, lo Bound[T], less func(a, b T) bool) bool {
+	if hi.Kind == Unbounded || lo.Kind == Unbounded {
+		return false
+	}
+	switch {
+	case less(hi.Value, lo.Value):
+		return true
+	case less(lo.Value, hi.Value):
+		return false
+	default:
+		// Equal endpoints: they only meet at that single point, which only
+		// counts as overlap if both sides include it.
+		return hi.Kind == Exclusive || lo.Kind == Exclusive
+	}
 }
 
 // IntervalNode represents a node in the interval tree.
@@ -19,19 +98,39 @@ type intervalNode[T comparable, V any] struct {
 	left     *intervalNode[T, V]
 	right    *intervalNode[T, V]
 	height   uint
+	maxEnd   T
 }
 
-// CollisionHandlers are functions that handlescollisions when inserting an interval into the interval tree.
-type CollisionHandler[T comparable, V any] func(existing Interval[T, V], new V) Interval[T, V]
+// CollisionHandlers are functions that handle collisions when inserting an
+// interval into the interval tree. newEnd is the End of the colliding
+// insert, kept separate from new so handlers can pair it with new in
+// existing.Values rather than overwriting the whole interval's End -- ranges
+// that share a Key commonly have different Ends.
+//
+// This signature, and the element type of Interval.Values (now ValueEnd[T,
+// V] rather than V), changed to fix a bug where a colliding insert's End
+// silently overwrote every other value's End at that key. Both are breaking
+// changes for any caller with a custom CollisionHandler or code reading
+// Interval.Values directly; the point-key API (Insert/Search/Delete) is
+// unaffected.
+type CollisionHandler[T comparable, V any] func(existing Interval[T, V], newEnd T, new V) Interval[T, V]
 
 // Replace is a collision handler that replaces the existing interval with the new interval.
-func Replace[T comparable, V any](existing Interval[T, V], new V) Interval[T, V] {
-	return Interval[T, V]{Key: existing.Key, Values: []V{new}}
+func Replace[T comparable, V any](existing Interval[T, V], newEnd T, new V) Interval[T, V] {
+	return Interval[T, V]{Key: existing.Key, End: newEnd, Values: []ValueEnd[T, V]{{End: newEnd, Value: new}}}
 }
 
 // Append is a collision handler that appends the new interval to the existing interval.
-func Append[T comparable, V any](existing Interval[T, V], new V) Interval[T, V] {
-	existing.Values = append(existing.Values, new)
+func Append[T comparable, V any](existing Interval[T, V], newEnd T, new V) Interval[T, V] {
+	existing.Values = append(existing.Values, ValueEnd[T, V]{End: newEnd, Value: new})
+	return existing
+}
+
+// Reject is a collision handler that discards the new value and keeps the
+// existing interval unchanged, for trees where a key collision should be
+// refused rather than merged. Pair it with InsertOrConflict for the common
+// case of rejecting on range overlap rather than exact key collision.
+func Reject[T comparable, V any](existing Interval[T, V], newEnd T, new V) Interval[T, V] {
 	return existing
 }
 
@@ -39,18 +138,58 @@ func Append[T comparable, V any](existing Interval[T, V], new V) Interval[T, V]
 type IntervalTree[T comparable, V any] struct {
 	mutex            sync.RWMutex
 	root             *intervalNode[T, V]
-	lessFunc         func(a, b T) bool
+	compare          Comparator[T]
 	collisionHandler CollisionHandler[T, V]
+	// generalized holds entries inserted via InsertBounded whose Range can't
+	// be represented as a native [Key, End) tree node -- i.e. anything with
+	// an Unbounded, Exclusive-low or Inclusive-high side. It's scanned
+	// linearly -- by OverlapBounded, InsertOrConflict, Conflicts and
+	// Difference -- rather than benefiting from maxEnd pruning. Visit,
+	// VisitRange, VisitLevel, All, Search, Overlap and Stab don't consult it
+	// at all; those are native-tree-only APIs.
+	generalized []generalizedEntry[T, V]
 }
 
-// NewIntervalTree creates a new instance of IntervalTree with the specified less function and collision handling strategy.
-func New[T comparable, V any](lessFunc func(a, b T) bool, collisionHandler CollisionHandler[T, V]) *IntervalTree[T, V] {
+// generalizedEntry is a value stored under a generalized Range rather than a
+// native [Key, End) tree node.
+type generalizedEntry[T comparable, V any] struct {
+	r     Range[T]
+	value V
+}
+
+// Comparator returns a negative number if a sorts before b, a positive
+// number if a sorts after b, and zero if they are equal.
+type Comparator[T any] func(a, b T) int
+
+// compareFromLess adapts a less function into a Comparator, for backward
+// compatibility with the tree's original less-based constructors.
+func compareFromLess[T any](less func(a, b T) bool) Comparator[T] {
+	return func(a, b T) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		default:
+			return 0
+		}
+	}
+}
+
+// NewCompare creates a new instance of IntervalTree with the specified
+// comparator and collision handling strategy.
+func NewCompare[T comparable, V any](compare Comparator[T], collisionHandler CollisionHandler[T, V]) *IntervalTree[T, V] {
 	return &IntervalTree[T, V]{
-		lessFunc:         lessFunc,
+		compare:          compare,
 		collisionHandler: collisionHandler,
 	}
 }
 
+// New creates a new instance of IntervalTree with the specified less function and collision handling strategy.
+func New[T comparable, V any](lessFunc func(a, b T) bool, collisionHandler CollisionHandler[T, V]) *IntervalTree[T, V] {
+	return NewCompare[T, V](compareFromLess(lessFunc), collisionHandler)
+}
+
 // Unique creates a new instance of IntervalTree with the specified less function and Replacement collision handling strategy.
 func Unique[T comparable, V any](lessFunc func(a, b T) bool) *IntervalTree[T, V] {
 	return New[T, V](lessFunc, Replace[T, V])
@@ -61,51 +200,229 @@ func Duplicates[T comparable, V any](lessFunc func(a, b T) bool) *IntervalTree[T
 	return New[T, V](lessFunc, Append[T, V])
 }
 
+// NewIntervalTree creates a new instance of IntervalTree for use as a true
+// interval tree: entries are inserted as [Begin, End) ranges via InsertRange
+// and queried with Overlap/Stab instead of the point-key Search. Entries that
+// share the same Begin key are appended rather than replaced, since distinct
+// overlapping ranges commonly start at the same point.
+func NewIntervalTree[T comparable, V any](lessFunc func(a, b T) bool) *IntervalTree[T, V] {
+	return New[T, V](lessFunc, Append[T, V])
+}
+
+// less reports whether a sorts strictly before b according to the tree's
+// comparator. It's used anywhere only the sign of the comparison matters.
+func (tree *IntervalTree[T, V]) less(a, b T) bool {
+	return tree.compare(a, b) < 0
+}
+
+// NewOrdered creates a new IntervalTree for a cmp.Ordered type using
+// cmp.Compare directly, avoiding the two less-func calls per comparison that
+// New's adapter needs just to detect equality. Equal keys are replaced, the
+// same default as Unique.
+func NewOrdered[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return NewCompare[T, V](cmp.Compare[T], Replace[T, V])
+}
+
 // Insert inserts a new value into the interval tree
 func (tree *IntervalTree[T, V]) Insert(key T, value V) {
 	tree.mutex.Lock()
 	defer tree.mutex.Unlock()
 
-	tree.root = tree.insertNode(tree.root, key, value)
+	tree.root = tree.insertNode(tree.root, key, key, value)
+}
+
+// InsertRange inserts a new value keyed by the half-open range [begin, end)
+// into the interval tree. Ranges are ordered by begin, the same as the
+// point-key Insert, and the End is tracked so Overlap and Stab can prune
+// subtrees that cannot possibly contain a match.
+func (tree *IntervalTree[T, V]) InsertRange(begin, end T, value V) {
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	tree.root = tree.insertNode(tree.root, begin, end, value)
+}
+
+// InsertOrConflict inserts value over iv's [Key, End) range unless it
+// overlaps an existing range -- native or generalized, i.e. anything
+// inserted via InsertRange or InsertBounded -- in which case the tree is
+// left unchanged and every overlapping entry is returned. This makes the
+// tree usable directly as a scheduling/allocation primitive (appointment
+// booking, byte-range locks, CIDR collision detection) without a separate
+// check-then-insert step racing under the lock.
+func (tree *IntervalTree[T, V]) InsertOrConflict(iv Interval[T, V], value V) (inserted bool, conflicts []Entry[T, V]) {
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	conflicts = make([]Entry[T, V], 0)
+	tree.overlapNodes(tree.root, iv.Key, iv.End, &conflicts)
+	tree.overlapGeneralized(iv.Key, iv.End, &conflicts)
+	if len(conflicts) > 0 {
+		return false, conflicts
+	}
+
+	tree.root = tree.insertNode(tree.root, iv.Key, iv.End, value)
+	return true, nil
+}
+
+// Conflicts returns every stored range -- native and generalized alike --
+// that overlaps iv's [Key, End) range, without mutating the tree.
+func (tree *IntervalTree[T, V]) Conflicts(iv Interval[T, V]) []Entry[T, V] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	results := make([]Entry[T, V], 0)
+	tree.overlapNodes(tree.root, iv.Key, iv.End, &results)
+	tree.overlapGeneralized(iv.Key, iv.End, &results)
+	return results
+}
+
+// overlapGeneralized appends every tree.generalized entry overlapping
+// [begin, end) to results. It's the generalized-range counterpart to
+// overlapNodes, consulted anywhere a query needs to see InsertBounded
+// entries that weren't natively representable as [Key, End) tree nodes.
+func (tree *IntervalTree[T, V]) overlapGeneralized(begin, end T, results *[]Entry[T, V]) {
+	query := Range[T]{Lo: Bound[T]{Kind: Inclusive, Value: begin}, Hi: Bound[T]{Kind: Exclusive, Value: end}}
+	for _, ge := range tree.generalized {
+		if Overlaps(ge.r, query, tree.less) {
+			*results = append(*results, Entry[T, V]{ge.r.Lo.Value, ge.value})
+		}
+	}
+}
+
+// InsertBounded inserts value over a generalized Range, which may be
+// unbounded or inclusive/exclusive on either side. Ranges that match the
+// tree's native [Inclusive, Exclusive) convention are stored as ordinary
+// tree nodes, same as InsertRange; anything else (Unbounded, Exclusive-low,
+// Inclusive-high) is kept in tree.generalized, an unordered side list that
+// OverlapBounded scans linearly via Overlaps rather than comparing bounds
+// against tree node ordering.
+func (tree *IntervalTree[T, V]) InsertBounded(r Range[T], value V) {
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	if r.Lo.Kind == Inclusive && r.Hi.Kind == Exclusive {
+		tree.root = tree.insertNode(tree.root, r.Lo.Value, r.Hi.Value, value)
+		return
+	}
+	tree.generalized = append(tree.generalized, generalizedEntry[T, V]{r, value})
+}
+
+// DeleteBounded removes the first entry in tree.generalized whose Range
+// equals r, reporting whether anything was removed. It only ever touches
+// tree.generalized; a Range that InsertBounded routed to a native tree node
+// (the [Inclusive, Exclusive) case) is removed with Delete instead, keyed by
+// r.Lo.Value, the same as InsertRange.
+func (tree *IntervalTree[T, V]) DeleteBounded(r Range[T]) bool {
+	tree.mutex.Lock()
+	defer tree.mutex.Unlock()
+
+	for i, ge := range tree.generalized {
+		if tree.boundsEqual(ge.r, r) {
+			tree.generalized = append(tree.generalized[:i], tree.generalized[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// boundsEqual and boundEqual report whether two Ranges/Bounds describe the
+// same endpoints, using the tree's Comparator for Value equality.
+func (tree *IntervalTree[T, V]) boundsEqual(a, b Range[T]) bool {
+	return tree.boundEqual(a.Lo, b.Lo) && tree.boundEqual(a.Hi, b.Hi)
+}
+
+func (tree *IntervalTree[T, V]) boundEqual(a, b Bound[T]) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+	if a.Kind == Unbounded {
+		return true
+	}
+	return tree.compare(a.Value, b.Value) == 0
+}
+
+// OverlapBounded returns every stored range -- native and generalized alike
+// -- that overlaps query, per Overlaps' bound semantics. Unlike Overlap,
+// this does not prune with maxEnd and is O(n) in the size of the tree.
+func (tree *IntervalTree[T, V]) OverlapBounded(query Range[T]) []Entry[T, V] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	results := make([]Entry[T, V], 0)
+	tree.visitAllNodes(tree.root, func(n *intervalNode[T, V]) {
+		for _, v := range n.interval.Values {
+			valueRange := Range[T]{Lo: Bound[T]{Kind: Inclusive, Value: n.interval.Key}, Hi: Bound[T]{Kind: Exclusive, Value: v.End}}
+			if Overlaps(valueRange, query, tree.less) {
+				results = append(results, Entry[T, V]{n.interval.Key, v.Value})
+			}
+		}
+	})
+
+	for _, ge := range tree.generalized {
+		if Overlaps(ge.r, query, tree.less) {
+			key := ge.r.Lo.Value
+			results = append(results, Entry[T, V]{key, ge.value})
+		}
+	}
+
+	return results
+}
+
+// visitAllNodes walks every node in the tree, unpruned, in no particular
+// order. It backs OverlapBounded's linear scan.
+func (tree *IntervalTree[T, V]) visitAllNodes(node *intervalNode[T, V], visit func(*intervalNode[T, V])) {
+	if node == nil {
+		return
+	}
+	visit(node)
+	tree.visitAllNodes(node.left, visit)
+	tree.visitAllNodes(node.right, visit)
 }
 
 // insertNode recursively inserts a new value into the interval tree.
-func (tree *IntervalTree[T, V]) insertNode(node *intervalNode[T, V], key T, value V) *intervalNode[T, V] {
+func (tree *IntervalTree[T, V]) insertNode(node *intervalNode[T, V], key, end T, value V) *intervalNode[T, V] {
 	if node == nil {
 		return &intervalNode[T, V]{
-			interval: Interval[T, V]{Key: key, Values: []V{value}},
+			interval: Interval[T, V]{Key: key, End: end, Values: []ValueEnd[T, V]{{End: end, Value: value}}},
 			left:     nil,
 			right:    nil,
 			height:   1,
+			maxEnd:   end,
 		}
 	}
 
-	switch {
-	case tree.lessFunc(key, node.interval.Key):
-		node.left = tree.insertNode(node.left, key, value)
-	case tree.lessFunc(node.interval.Key, key):
-		node.right = tree.insertNode(node.right, key, value)
+	switch c := tree.compare(key, node.interval.Key); {
+	case c < 0:
+		node.left = tree.insertNode(node.left, key, end, value)
+	case c > 0:
+		node.right = tree.insertNode(node.right, key, end, value)
 	default:
-		// Handle interval collision
-		node.interval = tree.collisionHandler(node.interval, value)
+		// Handle interval collision. end is not necessarily the same as any
+		// existing value's End, so it's threaded through to the handler
+		// rather than stomping node.interval.End for every value at this
+		// key; End is then recomputed as the max across all of them so
+		// maxEnd pruning still sees the full range covered here.
+		node.interval = tree.collisionHandler(node.interval, end, value)
+		node.interval.End = tree.maxValueEnd(node.interval.Values)
 	}
 
 	node.height = maxUint(getHeight(node.left), getHeight(node.right)) + 1
+	tree.updateMax(node)
 
 	// Rebalance the tree
 	switch balanceFactor := getBalance(node); {
 	// Left Left Case
-	case balanceFactor > imbalanceThreshold && tree.lessFunc(key, node.left.interval.Key):
+	case balanceFactor > imbalanceThreshold && tree.less(key, node.left.interval.Key):
 		return tree.rightRotate(node)
 	// Right Right Case
-	case balanceFactor < -imbalanceThreshold && tree.lessFunc(node.right.interval.Key, key):
+	case balanceFactor < -imbalanceThreshold && tree.less(node.right.interval.Key, key):
 		return tree.leftRotate(node)
 	// Left Right Case
-	case balanceFactor > imbalanceThreshold && tree.lessFunc(node.left.interval.Key, key):
+	case balanceFactor > imbalanceThreshold && tree.less(node.left.interval.Key, key):
 		node.left = tree.leftRotate(node.left)
 		return tree.rightRotate(node)
 	// Right Left Case
-	case balanceFactor < -imbalanceThreshold && tree.lessFunc(key, node.right.interval.Key):
+	case balanceFactor < -imbalanceThreshold && tree.less(key, node.right.interval.Key):
 		node.right = tree.rightRotate(node.right)
 		return tree.leftRotate(node)
 	}
@@ -113,7 +430,11 @@ func (tree *IntervalTree[T, V]) insertNode(node *intervalNode[T, V], key T, valu
 	return node
 }
 
-// Delete deletes an entry from the interval tree.
+// Delete deletes an entry from the interval tree by key. It only removes
+// native tree nodes, i.e. entries inserted via Insert/InsertRange or an
+// InsertBounded call that matched the tree's native [Inclusive, Exclusive)
+// convention; use DeleteBounded for anything else InsertBounded may have
+// routed into tree.generalized.
 func (tree *IntervalTree[T, V]) Delete(key T) {
 	tree.mutex.Lock()
 	defer tree.mutex.Unlock()
@@ -127,10 +448,10 @@ func (tree *IntervalTree[T, V]) deleteNode(node *intervalNode[T, V], key T) *int
 		return node
 	}
 
-	switch {
-	case tree.lessFunc(key, node.interval.Key):
+	switch c := tree.compare(key, node.interval.Key); {
+	case c < 0:
 		node.left = tree.deleteNode(node.left, key)
-	case tree.lessFunc(node.interval.Key, key):
+	case c > 0:
 		node.right = tree.deleteNode(node.right, key)
 	default:
 		// node is the node to be deleted
@@ -153,6 +474,7 @@ func (tree *IntervalTree[T, V]) deleteNode(node *intervalNode[T, V], key T) *int
 	}
 
 	node.height = maxUint(getHeight(node.left), getHeight(node.right)) + 1
+	tree.updateMax(node)
 
 	// Rebalance the tree
 	switch balanceFactor := getBalance(node); {
@@ -197,7 +519,7 @@ func (tree *IntervalTree[T, V]) Search(start, end T) []Entry[T, V] {
 	a := start
 	b := end
 	// swap start/end to ensure that there's always a positive range
-	if tree.lessFunc(end, start) {
+	if tree.less(end, start) {
 		a = end
 		b = start
 	}
@@ -212,20 +534,284 @@ func (tree *IntervalTree[T, V]) searchNodes(node *intervalNode[T, V], start, end
 	switch {
 	case node == nil:
 		return
-	case tree.lessFunc(end, node.interval.Key):
+	case tree.compare(end, node.interval.Key) < 0:
 		tree.searchNodes(node.left, start, end, results)
-	case tree.lessFunc(node.interval.Key, start):
+	case tree.compare(node.interval.Key, start) < 0:
 		tree.searchNodes(node.right, start, end, results)
 	default:
 		tree.searchNodes(node.left, start, end, results)
 		// Interval overlaps, flatten and append the (T, V) tuple to the results.
 		for _, v := range node.interval.Values {
-			*results = append(*results, Entry[T, V]{node.interval.Key, v})
+			*results = append(*results, Entry[T, V]{node.interval.Key, v.Value})
 		}
 		tree.searchNodes(node.right, start, end, results)
 	}
 }
 
+// Visit performs an in-order traversal of every native tree entry, calling
+// fn for each. It does not see generalized entries stashed in
+// tree.generalized by InsertBounded; use OverlapBounded with an unbounded
+// query for those. Traversal stops as soon as fn returns false. Unlike
+// Search/Overlap, Visit never materializes a result slice, so it's cheaper
+// for large trees or callers that only need the first few matches.
+func (tree *IntervalTree[T, V]) Visit(fn func(Entry[T, V]) bool) {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	tree.visitInOrder(tree.root, fn)
+}
+
+// visitInOrder walks node and its subtrees in order, returning false as soon
+// as fn asks to stop.
+func (tree *IntervalTree[T, V]) visitInOrder(node *intervalNode[T, V], fn func(Entry[T, V]) bool) bool {
+	if node == nil {
+		return true
+	}
+	if !tree.visitInOrder(node.left, fn) {
+		return false
+	}
+	for _, v := range node.interval.Values {
+		if !fn(Entry[T, V]{node.interval.Key, v.Value}) {
+			return false
+		}
+	}
+	return tree.visitInOrder(node.right, fn)
+}
+
+// VisitRange performs a bounded in-order traversal of the native tree
+// entries overlapping [start, end), calling fn for each; like Visit, it does
+// not see tree.generalized. Traversal stops as soon as fn returns false.
+func (tree *IntervalTree[T, V]) VisitRange(start, end T, fn func(Entry[T, V]) bool) {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	tree.visitOverlapping(tree.root, start, end, func(n *intervalNode[T, V]) bool {
+		for _, v := range n.interval.Values {
+			if !tree.less(start, v.End) {
+				continue
+			}
+			if !fn(Entry[T, V]{n.interval.Key, v.Value}) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// VisitLevel performs a breadth-first, level-order traversal, calling fn with
+// the depth of each entry's node (root is depth 0). This is mainly useful
+// for debugging tree balance and for pretty-printing. Traversal stops as
+// soon as fn returns false.
+func (tree *IntervalTree[T, V]) VisitLevel(fn func(depth uint, entry Entry[T, V]) bool) {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	if tree.root == nil {
+		return
+	}
+
+	type leveled struct {
+		node  *intervalNode[T, V]
+		depth uint
+	}
+	queue := []leveled{{tree.root, 0}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		for _, v := range cur.node.interval.Values {
+			if !fn(cur.depth, Entry[T, V]{cur.node.interval.Key, v.Value}) {
+				return
+			}
+		}
+		if cur.node.left != nil {
+			queue = append(queue, leveled{cur.node.left, cur.depth + 1})
+		}
+		if cur.node.right != nil {
+			queue = append(queue, leveled{cur.node.right, cur.depth + 1})
+		}
+	}
+}
+
+// All returns an in-order iterator over every native tree entry, for use
+// with Go's range-over-func (for entry := range tree.All()). Like Visit,
+// which it delegates to, it does not see generalized entries stashed in
+// tree.generalized by InsertBounded.
+func (tree *IntervalTree[T, V]) All() iter.Seq[Entry[T, V]] {
+	return func(yield func(Entry[T, V]) bool) {
+		tree.Visit(yield)
+	}
+}
+
+// Overlap returns every stored range that overlaps the half-open range
+// [begin, end), pruning whole subtrees using the maxEnd augmentation instead
+// of visiting every node (Cormen, Leiserson, Rivest & Stein, ch. 14.3).
+func (tree *IntervalTree[T, V]) Overlap(begin, end T) []Entry[T, V] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	results := make([]Entry[T, V], 0)
+	tree.overlapNodes(tree.root, begin, end, &results)
+	return results
+}
+
+// overlapNodes collects intervals overlapping [begin, end) in ascending key
+// order by delegating the pruned traversal to visitOverlapping.
+func (tree *IntervalTree[T, V]) overlapNodes(node *intervalNode[T, V], begin, end T, results *[]Entry[T, V]) {
+	tree.visitOverlapping(node, begin, end, func(n *intervalNode[T, V]) bool {
+		for _, v := range n.interval.Values {
+			if !tree.less(begin, v.End) {
+				continue
+			}
+			*results = append(*results, Entry[T, V]{n.interval.Key, v.Value})
+		}
+		return true
+	})
+}
+
+// visitOverlapping performs an in-order walk of the nodes overlapping
+// [begin, end), descending into the left subtree only when its maxEnd
+// reaches past begin and into the right subtree only when the current
+// node's key is still below end -- everything further right starts no
+// earlier than that key. node.interval.End (the max End across the node's
+// Values, see maxValueEnd) is only a node-level pruning gate here; visit
+// callbacks that care about a specific value's range still need to check
+// that value's own End. Shared by Overlap, Difference and VisitRange. The
+// walk stops as soon as visit returns false.
+func (tree *IntervalTree[T, V]) visitOverlapping(node *intervalNode[T, V], begin, end T, visit func(*intervalNode[T, V]) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if node.left != nil && tree.less(begin, node.left.maxEnd) {
+		if !tree.visitOverlapping(node.left, begin, end, visit) {
+			return false
+		}
+	}
+
+	if tree.less(node.interval.Key, end) && tree.less(begin, node.interval.End) {
+		if !visit(node) {
+			return false
+		}
+	}
+
+	if tree.less(node.interval.Key, end) {
+		return tree.visitOverlapping(node.right, begin, end, visit)
+	}
+	return true
+}
+
+// Difference returns the sub-ranges of [start, end) that are NOT covered by
+// any stored interval, native or generalized. It gathers every covering
+// range -- walking the tree's overlapping intervals plus any generalized
+// entries clamped to [start, end), see clampedGeneralizedRanges -- sorts
+// them by low endpoint, then keeps a cursor at the end of what has been
+// covered so far, emitting a gap whenever the next covering range starts
+// after the cursor.
+func (tree *IntervalTree[T, V]) Difference(start, end T) []Interval[T, V] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	var covering [][2]T
+	tree.visitOverlapping(tree.root, start, end, func(n *intervalNode[T, V]) bool {
+		covering = append(covering, [2]T{n.interval.Key, n.interval.End})
+		return true
+	})
+	covering = append(covering, tree.clampedGeneralizedRanges(start, end)...)
+	sort.Slice(covering, func(i, j int) bool { return tree.less(covering[i][0], covering[j][0]) })
+
+	gaps := make([]Interval[T, V], 0)
+	cursor := start
+	for _, c := range covering {
+		if tree.less(cursor, c[0]) {
+			gaps = append(gaps, Interval[T, V]{Key: cursor, End: tree.minT(c[0], end)})
+		}
+		cursor = tree.maxT(cursor, c[1])
+	}
+	if tree.less(cursor, end) {
+		gaps = append(gaps, Interval[T, V]{Key: cursor, End: end})
+	}
+	return gaps
+}
+
+// clampedGeneralizedRanges returns a [lo, hi) pair for every tree.generalized
+// entry overlapping [start, end), clamped to that query -- Unbounded sides,
+// and sides past the query, collapse to start/end. Difference's gap
+// computation works entirely in half-open [T, T) terms, so a generalized
+// entry's Inclusive/Exclusive distinction is otherwise not reflected here,
+// the same way InsertBounded's native-node fast path only captures
+// [Inclusive, Exclusive) ranges exactly.
+func (tree *IntervalTree[T, V]) clampedGeneralizedRanges(start, end T) [][2]T {
+	query := Range[T]{Lo: Bound[T]{Kind: Inclusive, Value: start}, Hi: Bound[T]{Kind: Exclusive, Value: end}}
+	var out [][2]T
+	for _, ge := range tree.generalized {
+		if !Overlaps(ge.r, query, tree.less) {
+			continue
+		}
+		lo := start
+		if ge.r.Lo.Kind != Unbounded && tree.less(start, ge.r.Lo.Value) {
+			lo = ge.r.Lo.Value
+		}
+		hi := end
+		if ge.r.Hi.Kind != Unbounded && tree.less(ge.r.Hi.Value, end) {
+			hi = ge.r.Hi.Value
+		}
+		out = append(out, [2]T{lo, hi})
+	}
+	return out
+}
+
+// minT and maxT return the lesser/greater of a and b according to the
+// tree's Comparator.
+func (tree *IntervalTree[T, V]) minT(a, b T) T {
+	if tree.less(b, a) {
+		return b
+	}
+	return a
+}
+
+func (tree *IntervalTree[T, V]) maxT(a, b T) T {
+	if tree.less(a, b) {
+		return b
+	}
+	return a
+}
+
+// Stab returns every stored range that contains point, i.e. every range
+// [Begin, End) with Begin <= point < End.
+func (tree *IntervalTree[T, V]) Stab(point T) []Entry[T, V] {
+	tree.mutex.RLock()
+	defer tree.mutex.RUnlock()
+
+	results := make([]Entry[T, V], 0)
+	tree.stabNodes(tree.root, point, &results)
+	return results
+}
+
+// stabNodes mirrors overlapNodes but for a single point, using the same
+// maxEnd pruning on the left and key-ordering pruning on the right.
+func (tree *IntervalTree[T, V]) stabNodes(node *intervalNode[T, V], point T, results *[]Entry[T, V]) {
+	if node == nil {
+		return
+	}
+
+	if node.left != nil && tree.less(point, node.left.maxEnd) {
+		tree.stabNodes(node.left, point, results)
+	}
+
+	if !tree.less(point, node.interval.Key) && tree.less(point, node.interval.End) {
+		for _, v := range node.interval.Values {
+			if tree.less(point, v.End) {
+				*results = append(*results, Entry[T, V]{node.interval.Key, v.Value})
+			}
+		}
+	}
+
+	if !tree.less(point, node.interval.Key) {
+		tree.stabNodes(node.right, point, results)
+	}
+}
+
 // rightRotate performs a right rotation on the given node and returns the new root.
 func (tree *IntervalTree[T, V]) rightRotate(node *intervalNode[T, V]) *intervalNode[T, V] {
 	l := node.left
@@ -235,7 +821,9 @@ func (tree *IntervalTree[T, V]) rightRotate(node *intervalNode[T, V]) *intervalN
 	node.left = lr
 
 	node.height = maxUint(getHeight(node.left), getHeight(node.right)) + 1
+	tree.updateMax(node)
 	l.height = maxUint(getHeight(l.left), getHeight(l.right)) + 1
+	tree.updateMax(l)
 
 	return l
 }
@@ -249,11 +837,40 @@ func (tree *IntervalTree[T, V]) leftRotate(node *intervalNode[T, V]) *intervalNo
 	node.right = rl
 
 	node.height = maxUint(getHeight(node.left), getHeight(node.right)) + 1
+	tree.updateMax(node)
 	r.height = maxUint(getHeight(r.left), getHeight(r.right)) + 1
+	tree.updateMax(r)
 
 	return r
 }
 
+// maxValueEnd returns the greatest End among values, which must be
+// non-empty. It's used to keep node.interval.End in sync with
+// node.interval.Values as the set of colliding values at a key changes.
+func (tree *IntervalTree[T, V]) maxValueEnd(values []ValueEnd[T, V]) T {
+	max := values[0].End
+	for _, v := range values[1:] {
+		if tree.less(max, v.End) {
+			max = v.End
+		}
+	}
+	return max
+}
+
+// updateMax recomputes node.maxEnd from its own End and the maxEnd already
+// recorded on its children. It must be called after any change to a node's
+// interval or children, the same way height is recomputed.
+func (tree *IntervalTree[T, V]) updateMax(node *intervalNode[T, V]) {
+	max := node.interval.End
+	if node.left != nil && tree.less(max, node.left.maxEnd) {
+		max = node.left.maxEnd
+	}
+	if node.right != nil && tree.less(max, node.right.maxEnd) {
+		max = node.right.maxEnd
+	}
+	node.maxEnd = max
+}
+
 // getHeight returns the height of the given node.
 func getHeight[T comparable, V any](node *intervalNode[T, V]) uint {
 	if node == nil {
@@ -161,6 +161,373 @@ func TestDeletion(t *testing.T) {
 	}, result)
 }
 
+func TestOverlap(t *testing.T) {
+	// Create a new interval-mode tree
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(1, 3, "A")  // [1,3)
+	tree.InsertRange(2, 6, "B")  // [2,6)
+	tree.InsertRange(8, 10, "C") // [8,10)
+
+	result := tree.Overlap(4, 9)
+
+	assertEqualValues(t, []Entry[int, string]{
+		{Key: 2, Value: "B"},
+		{Key: 8, Value: "C"},
+	}, result)
+}
+
+func TestRejectPreservesEnd(t *testing.T) {
+	// Reject must not let a rejected insert's End leak onto the value it
+	// kept: two InsertRange calls colliding on the same Key with different
+	// Ends used to overwrite node.interval.End unconditionally, corrupting
+	// Overlap results for the surviving value regardless of which handler
+	// was configured.
+	tree := New[int, string](func(a, b int) bool {
+		return a < b
+	}, Reject[int, string])
+
+	tree.InsertRange(1, 3, "A")  // [1,3), kept
+	tree.InsertRange(1, 20, "B") // same Key, rejected
+
+	assertEqualValues(t, []Entry[int, string]{
+		{Key: 1, Value: "A"},
+	}, tree.Overlap(1, 3))
+
+	// If the rejected End of 20 had leaked in, this would wrongly match "A".
+	if result := tree.Overlap(10, 15); len(result) != 0 {
+		t.Fatalf("expected no overlap past the kept range's End, got %+v", result)
+	}
+}
+
+func TestStab(t *testing.T) {
+	// Create a new interval-mode tree
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(1, 3, "A")  // [1,3)
+	tree.InsertRange(2, 6, "B")  // [2,6)
+	tree.InsertRange(8, 10, "C") // [8,10)
+
+	// 3 falls outside [1,3) but inside [2,6)
+	result := tree.Stab(3)
+
+	assertEqualValues(t, []Entry[int, string]{
+		{Key: 2, Value: "B"},
+	}, result)
+}
+
+func TestDifference(t *testing.T) {
+	// Create a new interval-mode tree
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(1, 3, "A") // [1,3)
+	tree.InsertRange(5, 7, "B") // [5,7)
+	tree.InsertRange(7, 9, "C") // [7,9), abuts B with no gap
+
+	// Query [0,10): gaps are [0,1), [3,5), and [9,10)
+	gaps := tree.Difference(0, 10)
+
+	if len(gaps) != 3 {
+		t.Fatalf("expected 3 gaps, got %d: %+v", len(gaps), gaps)
+	}
+	want := [][2]int{{0, 1}, {3, 5}, {9, 10}}
+	for i, g := range gaps {
+		if g.Key != want[i][0] || g.End != want[i][1] {
+			t.Fatalf("gap %d: expected [%d,%d), got [%v,%v)", i, want[i][0], want[i][1], g.Key, g.End)
+		}
+	}
+}
+
+func TestVisit(t *testing.T) {
+	tree := Unique[int, string](func(a, b int) bool {
+		return a < b
+	})
+	tree.Insert(3, "B")
+	tree.Insert(1, "A")
+	tree.Insert(5, "C")
+
+	var visited []int
+	tree.Visit(func(e Entry[int, string]) bool {
+		visited = append(visited, e.Key)
+		return true
+	})
+	if len(visited) != 3 || visited[0] != 1 || visited[1] != 3 || visited[2] != 5 {
+		t.Fatalf("expected in-order [1 3 5], got %v", visited)
+	}
+
+	// Stopping early should short-circuit the walk.
+	visited = nil
+	tree.Visit(func(e Entry[int, string]) bool {
+		visited = append(visited, e.Key)
+		return e.Key != 3
+	})
+	if len(visited) != 2 {
+		t.Fatalf("expected traversal to stop after the second entry, got %v", visited)
+	}
+}
+
+func TestVisitRange(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(0, 5, "short")  // [0,5)
+	tree.InsertRange(0, 100, "long") // [0,100), same Key as "short"
+	tree.InsertRange(8, 10, "C")     // [8,10)
+
+	// "short"'s own End (5) doesn't reach 50, even though it shares a Key
+	// with "long", whose End does -- this exercises the per-value End
+	// filtering VisitRange copies from overlapNodes.
+	var visited []string
+	tree.VisitRange(50, 60, func(e Entry[int, string]) bool {
+		visited = append(visited, e.Value)
+		return true
+	})
+	if len(visited) != 1 || visited[0] != "long" {
+		t.Fatalf("expected only long to overlap [50,60), got %v", visited)
+	}
+
+	// Stopping early should short-circuit the walk.
+	visited = nil
+	tree.VisitRange(0, 20, func(e Entry[int, string]) bool {
+		visited = append(visited, e.Value)
+		return false
+	})
+	if len(visited) != 1 {
+		t.Fatalf("expected traversal to stop after the first entry, got %v", visited)
+	}
+}
+
+func TestVisitLevel(t *testing.T) {
+	tree := Unique[int, string](func(a, b int) bool {
+		return a < b
+	})
+	for i := 1; i <= 7; i++ {
+		tree.Insert(i, "node")
+	}
+
+	depths := map[int]uint{}
+	tree.VisitLevel(func(depth uint, e Entry[int, string]) bool {
+		depths[e.Key] = depth
+		return true
+	})
+
+	// A balanced 7-node tree has the root at depth 0 and leaves at depth 2.
+	if depths[4] != 0 {
+		t.Fatalf("expected root key 4 at depth 0, got %d", depths[4])
+	}
+	if depths[1] != 2 || depths[7] != 2 {
+		t.Fatalf("expected leaves at depth 2, got 1:%d 7:%d", depths[1], depths[7])
+	}
+}
+
+func TestAll(t *testing.T) {
+	tree := Unique[int, string](func(a, b int) bool {
+		return a < b
+	})
+	tree.Insert(3, "B")
+	tree.Insert(1, "A")
+	tree.Insert(5, "C")
+
+	var visited []int
+	for e := range tree.All() {
+		visited = append(visited, e.Key)
+	}
+	if len(visited) != 3 || visited[0] != 1 || visited[1] != 3 || visited[2] != 5 {
+		t.Fatalf("expected in-order [1 3 5], got %v", visited)
+	}
+
+	// Stopping early should short-circuit the walk, same as Visit.
+	visited = nil
+	for e := range tree.All() {
+		visited = append(visited, e.Key)
+		if e.Key == 3 {
+			break
+		}
+	}
+	if len(visited) != 2 {
+		t.Fatalf("expected traversal to stop after the second entry, got %v", visited)
+	}
+}
+
+func TestInsertOrConflict(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	inserted, conflicts := tree.InsertOrConflict(Interval[int, string]{Key: 9, End: 12}, "appointment-1")
+	if !inserted || len(conflicts) != 0 {
+		t.Fatalf("expected a clean insert, got inserted=%v conflicts=%v", inserted, conflicts)
+	}
+
+	inserted, conflicts = tree.InsertOrConflict(Interval[int, string]{Key: 10, End: 14}, "appointment-2")
+	if inserted {
+		t.Fatalf("expected overlapping insert to be rejected")
+	}
+	assertEqualValues(t, []Entry[int, string]{{Key: 9, Value: "appointment-1"}}, conflicts)
+
+	// The rejected range must not have been inserted.
+	assertEqualValues(t, []Entry[int, string]{{Key: 9, Value: "appointment-1"}}, tree.Conflicts(Interval[int, string]{Key: 0, End: 20}))
+}
+
+func TestOverlapsBounds(t *testing.T) {
+	less := func(a, b int) bool { return a < b }
+
+	// [5,10) and [10,15) abut at an exclusive/inclusive boundary: no overlap.
+	a := Range[int]{Lo: Bound[int]{Kind: Inclusive, Value: 5}, Hi: Bound[int]{Kind: Exclusive, Value: 10}}
+	b := Range[int]{Lo: Bound[int]{Kind: Inclusive, Value: 10}, Hi: Bound[int]{Kind: Exclusive, Value: 15}}
+	if Overlaps(a, b, less) {
+		t.Fatalf("expected [5,10) and [10,15) not to overlap")
+	}
+
+	// "any time after 3" (Exclusive, Unbounded) overlaps [3,4].
+	after3 := Range[int]{Lo: Bound[int]{Kind: Exclusive, Value: 3}, Hi: Bound[int]{Kind: Unbounded}}
+	threeToFour := Range[int]{Lo: Bound[int]{Kind: Inclusive, Value: 3}, Hi: Bound[int]{Kind: Inclusive, Value: 4}}
+	if !Overlaps(after3, threeToFour, less) {
+		t.Fatalf("expected (3,inf) to overlap [3,4]")
+	}
+
+	// But (3,inf) does not overlap a range that ends exactly at 3.
+	upToThree := Range[int]{Lo: Bound[int]{Kind: Unbounded}, Hi: Bound[int]{Kind: Inclusive, Value: 3}}
+	if Overlaps(after3, upToThree, less) {
+		t.Fatalf("expected (3,inf) not to overlap (-inf,3]")
+	}
+}
+
+func TestOverlapBounded(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(0, 5, "native") // native [0,5)
+	tree.InsertBounded(Range[int]{Lo: Bound[int]{Kind: Exclusive, Value: 10}, Hi: Bound[int]{Kind: Unbounded}}, "after-10")
+
+	result := tree.OverlapBounded(Range[int]{Lo: Bound[int]{Kind: Inclusive, Value: 3}, Hi: Bound[int]{Kind: Inclusive, Value: 20}})
+
+	found := map[string]bool{}
+	for _, e := range result {
+		found[e.Value] = true
+	}
+	if !found["native"] || !found["after-10"] {
+		t.Fatalf("expected both native and generalized ranges in result, got %+v", result)
+	}
+}
+
+func TestInsertOrConflictSeesGeneralized(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	// (-inf, 100) has an Unbounded low side, so it can't be a native tree
+	// node and lands in tree.generalized instead.
+	tree.InsertBounded(Range[int]{Lo: Bound[int]{Kind: Unbounded}, Hi: Bound[int]{Kind: Exclusive, Value: 100}}, "early")
+
+	inserted, conflicts := tree.InsertOrConflict(Interval[int, string]{Key: 10, End: 20}, "double-booked")
+	if inserted {
+		t.Fatalf("expected insert overlapping a generalized range to be rejected")
+	}
+	assertEqualValues(t, []Entry[int, string]{{Key: 0, Value: "early"}}, conflicts)
+
+	assertEqualValues(t, []Entry[int, string]{{Key: 0, Value: "early"}}, tree.Conflicts(Interval[int, string]{Key: 10, End: 20}))
+
+	// Past the generalized range's End, there's no conflict.
+	inserted, conflicts = tree.InsertOrConflict(Interval[int, string]{Key: 100, End: 110}, "after")
+	if !inserted || len(conflicts) != 0 {
+		t.Fatalf("expected a clean insert past the generalized range, got inserted=%v conflicts=%v", inserted, conflicts)
+	}
+}
+
+func TestDifferenceSeesGeneralized(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	tree.InsertRange(0, 5, "native") // [0,5)
+	// (50, 60] is Exclusive-low/Inclusive-high, so it lands in tree.generalized.
+	tree.InsertBounded(Range[int]{Lo: Bound[int]{Kind: Exclusive, Value: 50}, Hi: Bound[int]{Kind: Inclusive, Value: 60}}, "generalized")
+
+	// Query [0,100): covered by native [0,5) and generalized (50,60], so the
+	// gaps are [5,50] and (60,100). clampedGeneralizedRanges treats the
+	// generalized range's bounds as half-open, so the gaps come out as
+	// [5,50) and [60,100) in T terms.
+	gaps := tree.Difference(0, 100)
+	want := [][2]int{{5, 50}, {60, 100}}
+	if len(gaps) != len(want) {
+		t.Fatalf("expected %d gaps, got %d: %+v", len(want), len(gaps), gaps)
+	}
+	for i, g := range gaps {
+		if g.Key != want[i][0] || g.End != want[i][1] {
+			t.Fatalf("gap %d: expected [%d,%d), got [%v,%v)", i, want[i][0], want[i][1], g.Key, g.End)
+		}
+	}
+}
+
+func TestDeleteBounded(t *testing.T) {
+	tree := NewIntervalTree[int, string](func(a, b int) bool {
+		return a < b
+	})
+
+	r := Range[int]{Lo: Bound[int]{Kind: Unbounded}, Hi: Bound[int]{Kind: Exclusive, Value: 100}}
+	tree.InsertBounded(r, "early")
+
+	if !tree.DeleteBounded(r) {
+		t.Fatalf("expected DeleteBounded to find and remove the generalized entry")
+	}
+
+	// Once removed, it must no longer show up in any overlap-aware query.
+	_, conflicts := tree.InsertOrConflict(Interval[int, string]{Key: 10, End: 20}, "now-fits")
+	assertEqualValues(t, []Entry[int, string]{}, conflicts)
+
+	if tree.DeleteBounded(r) {
+		t.Fatalf("expected a second DeleteBounded for the same Range to report nothing removed")
+	}
+}
+
+func TestNewOrdered(t *testing.T) {
+	// Create a new IntervalTree using the cmp.Ordered-backed constructor
+	tree := NewOrdered[int, string]()
+
+	tree.Insert(1, "A")
+	tree.Insert(3, "B")
+	tree.Insert(5, "C")
+
+	// NewOrdered defaults to Replace on key collision, same as Unique
+	tree.Insert(3, "B2")
+
+	result := tree.Search(1, 5)
+
+	assertEqualValues(t, []Entry[int, string]{
+		{Key: 1, Value: "A"},
+		{Key: 3, Value: "B2"},
+		{Key: 5, Value: "C"},
+	}, result)
+}
+
+func TestNewCompare(t *testing.T) {
+	// Create a new IntervalTree using an explicit 3-way Comparator
+	tree := NewCompare[int, string](func(a, b int) int {
+		return a - b
+	}, Append[int, string])
+
+	tree.Insert(2, "A")
+	tree.Insert(1, "B")
+	tree.Insert(2, "C")
+
+	result := tree.Search(1, 2)
+
+	assertEqualValues(t, []Entry[int, string]{
+		{Key: 1, Value: "B"},
+		{Key: 2, Value: "A"},
+		{Key: 2, Value: "C"},
+	}, result)
+}
+
 func assertEqualValues[T, V comparable](t *testing.T, a, b []Entry[T, V]) {
 	if len(a) != len(b) {
 		t.Fatalf("expected %d entries, got %d", len(a), len(b))